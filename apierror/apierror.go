@@ -0,0 +1,30 @@
+// Package apierror defines the error envelope shared by every HTTP
+// handler and middleware, so responses stay consistent across packages.
+package apierror
+
+import "github.com/gin-gonic/gin"
+
+// FieldError describes a single invalid field, as reported by the validator.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Response is the consistent error envelope returned by every handler.
+type Response struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// Respond writes a consistent error envelope to the client.
+func Respond(c *gin.Context, status int, code, message string) {
+	c.IndentedJSON(status, Response{Code: code, Message: message})
+}
+
+// Abort writes the error envelope and stops the middleware chain. Use
+// this instead of Respond inside middleware that must not call the
+// downstream handler.
+func Abort(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, Response{Code: code, Message: message})
+}
@@ -0,0 +1,59 @@
+// Package auth issues and validates the JWTs used to authenticate
+// checkout/return requests.
+package auth
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when a token fails to parse or has expired.
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+const tokenTTL = 24 * time.Hour
+
+// Claims is the JWT payload issued at login.
+type Claims struct {
+	UserID string `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// secretKey returns the HMAC signing key, read from JWT_SECRET. A dev
+// default is used when unset so the API still runs locally.
+func secretKey() []byte {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return []byte("dev-secret-change-me")
+}
+
+// GenerateToken issues a signed JWT for userID, valid for 24 hours.
+func GenerateToken(userID string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secretKey())
+}
+
+// ParseToken validates tokenString and returns its claims.
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return secretKey(), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"example/go-rest-api/apierror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextUserIDKey is the Gin context key RequireAuth stores the
+// authenticated user's ID under.
+const ContextUserIDKey = "userID"
+
+// RequireAuth validates the "Authorization: Bearer <token>" header and
+// stores the caller's user ID in the request context, so downstream
+// handlers can look it up with UserID.
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+			apierror.Abort(c, http.StatusUnauthorized, "missing_authorization", "Missing or malformed Authorization header.")
+			return
+		}
+
+		claims, err := ParseToken(parts[1])
+		if err != nil {
+			apierror.Abort(c, http.StatusUnauthorized, "invalid_token", "Invalid or expired token.")
+			return
+		}
+
+		c.Set(ContextUserIDKey, claims.UserID)
+		c.Next()
+	}
+}
+
+// UserID returns the authenticated user's ID, as stored by RequireAuth.
+func UserID(c *gin.Context) string {
+	id, _ := c.Get(ContextUserIDKey)
+	userID, _ := id.(string)
+	return userID
+}
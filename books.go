@@ -0,0 +1,542 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"example/go-rest-api/auth"
+	"example/go-rest-api/middleware"
+	"example/go-rest-api/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type book = storage.Book
+
+// CreateBookInput is the request body for POST /books.
+type CreateBookInput struct {
+	ID       string `json:"id" binding:"required"`
+	Title    string `json:"title" binding:"required,min=1"`
+	Author   string `json:"author" binding:"required,min=1"`
+	Quantity int    `json:"quantity" binding:"gte=0"`
+}
+
+// ReplaceBookInput is the request body for PUT /books/:id.
+type ReplaceBookInput struct {
+	Title    string `json:"title" binding:"required,min=1"`
+	Author   string `json:"author" binding:"required,min=1"`
+	Quantity int    `json:"quantity" binding:"gte=0"`
+}
+
+// UpdateBookInput carries a partial update for PATCH /books/:id. Fields
+// left nil are not modified.
+type UpdateBookInput struct {
+	Title    *string `json:"title" binding:"omitempty,min=1"`
+	Author   *string `json:"author" binding:"omitempty,min=1"`
+	Quantity *int    `json:"quantity" binding:"omitempty,gte=0"`
+}
+
+// loanDuration is how long a checked-out book may be kept before it is due.
+const loanDuration = 14 * 24 * time.Hour
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// sortableColumns maps sort_column values to a less-than comparator.
+var sortableColumns = map[string]func(a, b book) bool{
+	"id":       func(a, b book) bool { return a.ID < b.ID },
+	"title":    func(a, b book) bool { return a.Title < b.Title },
+	"author":   func(a, b book) bool { return a.Author < b.Author },
+	"quantity": func(a, b book) bool { return a.Quantity < b.Quantity },
+}
+
+// BooksMeta describes the page returned by GET /books.
+type BooksMeta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// BooksResponse is the envelope returned by GET /books.
+type BooksResponse struct {
+	Data []book    `json:"data"`
+	Meta BooksMeta `json:"meta"`
+}
+
+// store is the active book storage backend, selected in main via STORAGE_DRIVER.
+var store storage.BookStore
+
+// seedBooks is the starting catalogue used by the in-memory backend.
+var seedBooks = []book{
+	{ID: "1", Title: "In Search of Lost Time", Author: "Marcel Proust", Quantity: 2},
+	{ID: "2", Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Quantity: 5},
+	{ID: "3", Title: "War and Peace", Author: "Leo Tolstoy", Quantity: 6},
+}
+
+// newStores builds the book, user and loan storage backends selected by
+// the STORAGE_DRIVER env var (defaulting to "memory"). DB_DSN is passed
+// through to backends that need a connection string. All three stores
+// share the same backend and connection, so registering a user or
+// checking out a book persists consistently with the book catalogue.
+func newStores() (storage.BookStore, storage.UserStore, storage.LoanStore, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	dsn := os.Getenv("DB_DSN")
+
+	switch driver {
+	case "", "memory":
+		return storage.NewMemoryStore(seedBooks), storage.NewMemoryUserStore(), storage.NewMemoryLoanStore(), nil
+	case "sqlite":
+		if dsn == "" {
+			dsn = "library.db"
+		}
+
+		db, err := storage.OpenSQLiteDB(dsn)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		books, err := storage.NewSQLiteStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		users, err := storage.NewSQLiteUserStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		loanStore, err := storage.NewSQLiteLoanStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return books, users, loanStore, nil
+	case "mongo":
+		if dsn == "" {
+			return nil, nil, nil, errors.New("DB_DSN is required for the mongo storage driver")
+		}
+
+		db, err := storage.ConnectMongo(dsn, "library")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		books, err := storage.NewMongoStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		users, err := storage.NewMongoUserStore(db)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return books, users, storage.NewMongoLoanStore(db), nil
+	default:
+		return nil, nil, nil, errors.New("unknown STORAGE_DRIVER: " + driver)
+	}
+}
+
+// @Summary Get all books
+// @Description Retrieve a paginated, sorted and filtered list of books
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param limit query int false "Max results per page (default 50, max 500)"
+// @Param offset query int false "Number of results to skip"
+// @Param page query int false "Page number, 1-indexed (alternative to offset)"
+// @Param page_size query int false "Results per page (alternative to limit, used with page)"
+// @Param sort_column query string false "Column to sort by: id, title, author or quantity"
+// @Param sort_order query string false "asc or desc (default asc)"
+// @Param author query string false "Filter by author (case-insensitive substring)"
+// @Param available query bool false "Filter to books with quantity > 0 when true"
+// @Success 200 {object} BooksResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /books [get]
+func getBooks(c *gin.Context) {
+	all, err := store.List()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "storage_error", "Could not list books.")
+		return
+	}
+
+	if author := strings.ToLower(c.Query("author")); author != "" {
+		filtered := all[:0:0]
+		for _, b := range all {
+			if strings.Contains(strings.ToLower(b.Author), author) {
+				filtered = append(filtered, b)
+			}
+		}
+		all = filtered
+	}
+
+	if availableStr := c.Query("available"); availableStr != "" {
+		available, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "invalid_available", "available must be true or false.")
+			return
+		}
+
+		filtered := all[:0:0]
+		for _, b := range all {
+			if (b.Quantity > 0) == available {
+				filtered = append(filtered, b)
+			}
+		}
+		all = filtered
+	}
+
+	if sortColumn := c.Query("sort_column"); sortColumn != "" {
+		less, ok := sortableColumns[sortColumn]
+		if !ok {
+			respondError(c, http.StatusBadRequest, "invalid_sort_column", "sort_column must be one of id, title, author or quantity.")
+			return
+		}
+
+		sortOrder := c.DefaultQuery("sort_order", "asc")
+		switch sortOrder {
+		case "asc":
+			sort.Slice(all, func(i, j int) bool { return less(all[i], all[j]) })
+		case "desc":
+			sort.Slice(all, func(i, j int) bool { return less(all[j], all[i]) })
+		default:
+			respondError(c, http.StatusBadRequest, "invalid_sort_order", "sort_order must be asc or desc.")
+			return
+		}
+	}
+
+	limit, offset, err := parsePageParams(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "invalid_page_params", err.Error())
+		return
+	}
+
+	total := len(all)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	c.IndentedJSON(http.StatusOK, BooksResponse{
+		Data: all[offset:end],
+		Meta: BooksMeta{Total: total, Limit: limit, Offset: offset},
+	})
+}
+
+// parsePageParams reads limit/offset (or page/page_size) from the query
+// string, applying the default and maximum page size.
+func parsePageParams(c *gin.Context) (limit, offset int, err error) {
+	limit = defaultListLimit
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		limit, err = strconv.Atoi(pageSizeStr)
+		if err != nil || limit < 0 {
+			return 0, 0, errors.New("page_size must be a non-negative integer")
+		}
+	} else if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return 0, 0, errors.New("limit must be a non-negative integer")
+		}
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return 0, 0, errors.New("page must be a positive integer")
+		}
+		return limit, (page - 1) * limit, nil
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+	}
+
+	return limit, offset, nil
+}
+
+// @Summary Get a book by ID
+// @Description Retrieve a specific book by its ID
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 200 {object} book
+// @Failure 404 {object} ErrorResponse
+// @Router /books/{id} [get]
+func bookById(c *gin.Context) {
+	id := c.Param("id")
+	book, err := store.Get(id)
+
+	if err != nil {
+		respondError(c, http.StatusNotFound, "not_found", "Book not found!")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, book)
+}
+
+// @Summary Checkout a book
+// @Description Decrease the quantity of a book when checked out and record the loan against the caller
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param id query string true "Book ID"
+// @Security BearerAuth
+// @Success 200 {object} book
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /checkout [put]
+func checkoutBook(c *gin.Context) {
+	id, ok := c.GetQuery("id")
+
+	if !ok {
+		respondError(c, http.StatusBadRequest, "missing_id", "Missing id query parameter.")
+		return
+	}
+
+	if _, err := store.Get(id); err != nil {
+		respondError(c, http.StatusNotFound, "not_found", "Book not found!")
+		return
+	}
+
+	book, err := store.AdjustQuantity(id, -1)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "not_available", "Book not available.")
+		return
+	}
+
+	now := time.Now()
+	if _, err := loans.Create(storage.Loan{
+		ID:           uuid.NewString(),
+		UserID:       auth.UserID(c),
+		BookID:       id,
+		CheckedOutAt: now,
+		DueAt:        now.Add(loanDuration),
+	}); err != nil {
+		respondError(c, http.StatusInternalServerError, "storage_error", "Could not record loan.")
+		return
+	}
+
+	middleware.BookCheckoutsTotal.Inc()
+	c.IndentedJSON(http.StatusOK, book)
+}
+
+// @Summary Return a book
+// @Description Increase the quantity of a book when returned, provided the caller is the one who checked it out
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param id query string true "Book ID"
+// @Security BearerAuth
+// @Success 200 {object} book
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /return [put]
+func returnBook(c *gin.Context) {
+	id, ok := c.GetQuery("id")
+
+	if !ok {
+		respondError(c, http.StatusBadRequest, "missing_id", "Missing id query parameter.")
+		return
+	}
+
+	if _, err := store.Get(id); err != nil {
+		respondError(c, http.StatusNotFound, "not_found", "Book not found!")
+		return
+	}
+
+	loan, err := loans.ActiveForUserAndBook(auth.UserID(c), id)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "not_checked_out", "You have not checked out this book.")
+		return
+	}
+
+	book, err := store.AdjustQuantity(id, 1)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "not_available", "Book not available.")
+		return
+	}
+
+	if err := loans.MarkReturned(loan.ID); err != nil {
+		respondError(c, http.StatusInternalServerError, "storage_error", "Could not record return.")
+		return
+	}
+
+	middleware.BookReturnsTotal.Inc()
+	c.IndentedJSON(http.StatusOK, book)
+}
+
+// @Summary Create a new book
+// @Description Add a new book to the library
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param book body CreateBookInput true "Book data"
+// @Success 201 {object} book
+// @Failure 400 {object} ErrorResponse
+// @Router /books [post]
+func createBook(c *gin.Context) {
+	var input CreateBookInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	if _, err := store.Get(input.ID); err == nil {
+		respondError(c, http.StatusBadRequest, "duplicate_id", "A book with this ID already exists.")
+		return
+	}
+
+	created, err := store.Create(book{ID: input.ID, Title: input.Title, Author: input.Author, Quantity: input.Quantity})
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "duplicate_id", "A book with this ID already exists.")
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, created)
+}
+
+// @Summary Replace a book
+// @Description Fully replace a book's details
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param book body ReplaceBookInput true "Book data"
+// @Success 200 {object} book
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /books/{id} [put]
+func replaceBook(c *gin.Context) {
+	id := c.Param("id")
+
+	var input ReplaceBookInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	updated, err := store.Update(book{ID: id, Title: input.Title, Author: input.Author, Quantity: input.Quantity})
+	if err != nil {
+		respondError(c, http.StatusNotFound, "not_found", "Book not found!")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, updated)
+}
+
+// @Summary Partially update a book
+// @Description Update one or more fields of a book
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param book body UpdateBookInput true "Fields to update"
+// @Success 200 {object} book
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /books/{id} [patch]
+func updateBook(c *gin.Context) {
+	id := c.Param("id")
+
+	existing, err := store.Get(id)
+	if err != nil {
+		respondError(c, http.StatusNotFound, "not_found", "Book not found!")
+		return
+	}
+
+	var input UpdateBookInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	updated := *existing
+	if input.Title != nil {
+		updated.Title = *input.Title
+	}
+	if input.Author != nil {
+		updated.Author = *input.Author
+	}
+	if input.Quantity != nil {
+		updated.Quantity = *input.Quantity
+	}
+
+	saved, err := store.Update(updated)
+	if err != nil {
+		respondError(c, http.StatusNotFound, "not_found", "Book not found!")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, saved)
+}
+
+// @Summary Delete a book
+// @Description Remove a book from the library. Deleting a book that is
+// @Description already gone is not an error.
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 204
+// @Router /books/{id} [delete]
+func deleteBook(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := store.Delete(id); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		respondError(c, http.StatusInternalServerError, "storage_error", "Could not delete book.")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Search books
+// @Description Search books by title and/or author (case-insensitive, substring match)
+// @Tags Books
+// @Accept  json
+// @Produce  json
+// @Param title query string false "Title filter"
+// @Param author query string false "Author filter"
+// @Success 200 {array} book
+// @Router /books/search [get]
+func searchBooks(c *gin.Context) {
+	title := strings.ToLower(c.Query("title"))
+	author := strings.ToLower(c.Query("author"))
+
+	all, err := store.List()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "storage_error", "Could not search books.")
+		return
+	}
+
+	results := make([]book, 0, len(all))
+	for _, b := range all {
+		if title != "" && !strings.Contains(strings.ToLower(b.Title), title) {
+			continue
+		}
+		if author != "" && !strings.Contains(strings.ToLower(b.Author), author) {
+			continue
+		}
+		results = append(results, b)
+	}
+
+	c.IndentedJSON(http.StatusOK, results)
+}
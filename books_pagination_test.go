@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetBooksDefaultPagination(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got BooksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Meta.Total != 2 || got.Meta.Limit != defaultListLimit || got.Meta.Offset != 0 {
+		t.Fatalf("unexpected meta: %+v", got.Meta)
+	}
+	if len(got.Data) != 2 {
+		t.Fatalf("expected both seed books, got %+v", got.Data)
+	}
+}
+
+func TestGetBooksLimitAndOffset(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?limit=1&offset=1", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got BooksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(got.Data) != 1 || got.Meta.Limit != 1 || got.Meta.Offset != 1 {
+		t.Fatalf("unexpected page: %+v", got)
+	}
+}
+
+func TestGetBooksPageAndPageSize(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?page=2&page_size=1", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got BooksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(got.Data) != 1 || got.Meta.Offset != 1 {
+		t.Fatalf("expected the second book, got %+v", got)
+	}
+}
+
+func TestGetBooksOffsetBeyondTotalReturnsEmptyPage(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?offset=100", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got BooksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(got.Data) != 0 {
+		t.Fatalf("expected no data past the end, got %+v", got.Data)
+	}
+}
+
+func TestGetBooksLimitClampedToMax(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?limit=100000", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got BooksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Meta.Limit != maxListLimit {
+		t.Fatalf("expected limit clamped to %d, got %d", maxListLimit, got.Meta.Limit)
+	}
+}
+
+func TestGetBooksInvalidOffsetIsRejected(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?offset=-1", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetBooksInvalidSortOrderIsRejected(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?sort_column=title&sort_order=sideways", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetBooksInvalidAvailableIsRejected(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?available=maybe", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetBooksSortByQuantityDesc(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books?sort_column=quantity&sort_order=desc", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got BooksResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(got.Data) != 2 || got.Data[0].ID != "2" || got.Data[1].ID != "1" {
+		t.Fatalf("expected books sorted by quantity desc, got %+v", got.Data)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"example/go-rest-api/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	store = storage.NewMemoryStore([]book{
+		{ID: "1", Title: "In Search of Lost Time", Author: "Marcel Proust", Quantity: 2},
+		{ID: "2", Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Quantity: 5},
+	})
+
+	router := gin.New()
+	router.GET("/books", getBooks)
+	router.GET("/books/search", searchBooks)
+	router.GET("/books/:id", bookById)
+	router.POST("/books", createBook)
+	router.PUT("/books/:id", replaceBook)
+	router.PATCH("/books/:id", updateBook)
+	router.DELETE("/books/:id", deleteBook)
+	return router
+}
+
+func doRequest(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var reqBody io.Reader
+	if body != nil {
+		b, _ := json.Marshal(body)
+		reqBody = bytes.NewReader(b)
+	}
+
+	req := httptest.NewRequest(method, path, reqBody)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestReplaceBook(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodPut, "/books/1", ReplaceBookInput{Title: "New Title", Author: "New Author", Quantity: 9})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got book
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Title != "New Title" || got.Author != "New Author" || got.Quantity != 9 {
+		t.Fatalf("unexpected book after replace: %+v", got)
+	}
+}
+
+func TestReplaceBookNotFound(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodPut, "/books/missing", ReplaceBookInput{Title: "X", Author: "Y", Quantity: 1})
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestUpdateBookPartial(t *testing.T) {
+	router := newTestRouter()
+
+	newQuantity := 42
+	rec := doRequest(router, http.MethodPatch, "/books/1", UpdateBookInput{Quantity: &newQuantity})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got book
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Quantity != 42 || got.Title != "In Search of Lost Time" {
+		t.Fatalf("unexpected book after partial update: %+v", got)
+	}
+}
+
+func TestDeleteBookIsIdempotent(t *testing.T) {
+	router := newTestRouter()
+
+	first := doRequest(router, http.MethodDelete, "/books/1", nil)
+	if first.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 on first delete, got %d", first.Code)
+	}
+
+	second := doRequest(router, http.MethodDelete, "/books/1", nil)
+	if second.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 on repeat delete, got %d", second.Code)
+	}
+
+	getRec := doRequest(router, http.MethodGet, "/books/1", nil)
+	if getRec.Code != http.StatusNotFound {
+		t.Fatalf("expected book to be gone after delete, got %d", getRec.Code)
+	}
+}
+
+func TestSearchBooksFiltersByTitleAndAuthor(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodGet, "/books/search?title=great", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var results []book
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "2" {
+		t.Fatalf("expected only the Gatsby book, got %+v", results)
+	}
+
+	rec = doRequest(router, http.MethodGet, "/books/search?author=nobody", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var empty []book
+	if err := json.Unmarshal(rec.Body.Bytes(), &empty); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Fatalf("expected no matches, got %+v", empty)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreateBookRequiresFields(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodPost, "/books", CreateBookInput{})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if got.Code != "validation_error" || len(got.Details) == 0 {
+		t.Fatalf("expected field-level validation details, got %+v", got)
+	}
+}
+
+func TestCreateBookRejectsNegativeQuantity(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodPost, "/books", CreateBookInput{ID: "3", Title: "Dune", Author: "Frank Herbert", Quantity: -1})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateBookSucceedsWithValidInput(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodPost, "/books", CreateBookInput{ID: "3", Title: "Dune", Author: "Frank Herbert", Quantity: 1})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCreateBookRejectsDuplicateID(t *testing.T) {
+	router := newTestRouter()
+
+	rec := doRequest(router, http.MethodPost, "/books", CreateBookInput{ID: "1", Title: "Another Copy", Author: "Someone", Quantity: 1})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUpdateBookRejectsBlankTitle(t *testing.T) {
+	router := newTestRouter()
+
+	blank := ""
+	rec := doRequest(router, http.MethodPatch, "/books/1", UpdateBookInput{Title: &blank})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
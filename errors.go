@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+
+	"example/go-rest-api/apierror"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single invalid field, as reported by the validator.
+type FieldError = apierror.FieldError
+
+// ErrorResponse is the consistent error envelope returned by every handler.
+type ErrorResponse = apierror.Response
+
+// bindJSON binds the request body into input, writing a consistent
+// validation error response and returning false on failure.
+func bindJSON(c *gin.Context, input interface{}) bool {
+	if err := c.ShouldBindJSON(input); err != nil {
+		respondBindError(c, err)
+		return false
+	}
+	return true
+}
+
+// respondError writes a consistent error envelope to the client.
+func respondError(c *gin.Context, status int, code, message string) {
+	apierror.Respond(c, status, code, message)
+}
+
+// respondBindError inspects a c.ShouldBindJSON error and responds with
+// field-level details when it came from struct tag validation.
+func respondBindError(c *gin.Context, err error) {
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		respondError(c, http.StatusBadRequest, "invalid_body", "Request body is invalid.")
+		return
+	}
+
+	details := make([]FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		details = append(details, FieldError{Field: fe.Field(), Message: fieldErrorMessage(fe)})
+	}
+
+	c.IndentedJSON(http.StatusBadRequest, ErrorResponse{
+		Code:    "validation_error",
+		Message: "Request failed validation.",
+		Details: details,
+	})
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required."
+	case "min":
+		return fe.Field() + " must be at least " + fe.Param() + " characters long."
+	case "gte":
+		return fe.Field() + " must be greater than or equal to " + fe.Param() + "."
+	default:
+		return fe.Field() + " is invalid."
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Liveness probe
+// @Description Reports that the process is running
+// @Tags Health
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Router /healthz [get]
+func healthz(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Summary Readiness probe
+// @Description Reports whether the storage backend is reachable
+// @Tags Health
+// @Produce  json
+// @Success 200 {object} gin.H
+// @Failure 503 {object} ErrorResponse
+// @Router /readyz [get]
+func readyz(c *gin.Context) {
+	if _, err := store.List(); err != nil {
+		respondError(c, http.StatusServiceUnavailable, "storage_unreachable", "Storage backend is not reachable.")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// Logging emits one structured JSON log line per request, tagged with
+// the request ID assigned by RequestID.
+func Logging() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		log.Info().
+			Str("request_id", RequestIDFromContext(c)).
+			Str("method", c.Request.Method).
+			Str("path", c.FullPath()).
+			Int("status", c.Writer.Status()).
+			Dur("latency", time.Since(start)).
+			Msg("request handled")
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests processed.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "path"})
+
+	// BookCheckoutsTotal counts successful checkouts, incremented by the
+	// checkout handler.
+	BookCheckoutsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "library_book_checkouts_total",
+		Help: "Total number of successful book checkouts.",
+	})
+
+	// BookReturnsTotal counts successful returns, incremented by the
+	// return handler.
+	BookReturnsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "library_book_returns_total",
+		Help: "Total number of successful book returns.",
+	})
+)
+
+// Metrics records request counts and latency for every request.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		path := c.FullPath()
+		if path == "" {
+			path = "unmatched"
+		}
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, path, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, path).Observe(time.Since(start).Seconds())
+	}
+}
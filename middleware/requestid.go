@@ -0,0 +1,36 @@
+// Package middleware holds cross-cutting Gin middleware: request IDs,
+// structured logging and Prometheus metrics.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header checked for an existing request ID and
+// echoed back on the response.
+const RequestIDHeader = "X-Request-ID"
+
+const contextRequestIDKey = "requestID"
+
+// RequestID assigns a request ID to every request, generating a UUID
+// when the client didn't supply one via X-Request-ID.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = uuid.NewString()
+		}
+
+		c.Set(contextRequestIDKey, id)
+		c.Writer.Header().Set(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// RequestIDFromContext returns the request ID assigned by RequestID.
+func RequestIDFromContext(c *gin.Context) string {
+	id, _ := c.Get(contextRequestIDKey)
+	requestID, _ := id.(string)
+	return requestID
+}
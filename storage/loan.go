@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"sync"
+	"time"
+)
+
+// Loan records that a user checked out a copy of a book. bson tags are
+// explicit (rather than relying on the driver's default lowercasing)
+// since the Mongo backend filters and updates on these exact key names.
+type Loan struct {
+	ID           string     `json:"id" bson:"id"`
+	UserID       string     `json:"user_id" bson:"user_id"`
+	BookID       string     `json:"book_id" bson:"book_id"`
+	CheckedOutAt time.Time  `json:"checked_out_at" bson:"checked_out_at"`
+	DueAt        time.Time  `json:"due_at" bson:"due_at"`
+	ReturnedAt   *time.Time `json:"returned_at,omitempty" bson:"returned_at,omitempty"`
+}
+
+// LoanStore is implemented by every loan storage backend.
+type LoanStore interface {
+	Create(l Loan) (*Loan, error)
+	// ActiveForUserAndBook returns the caller's open loan for bookID, if
+	// any, so a return can be refused for copies someone else checked out.
+	ActiveForUserAndBook(userID, bookID string) (*Loan, error)
+	ListActiveForUser(userID string) ([]Loan, error)
+	MarkReturned(id string) error
+}
+
+// MemoryLoanStore is an in-memory LoanStore backed by a sync.RWMutex.
+type MemoryLoanStore struct {
+	mu    sync.RWMutex
+	loans []Loan
+}
+
+// NewMemoryLoanStore returns an empty MemoryLoanStore.
+func NewMemoryLoanStore() *MemoryLoanStore {
+	return &MemoryLoanStore{}
+}
+
+func (s *MemoryLoanStore) Create(l Loan) (*Loan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.loans = append(s.loans, l)
+	loan := l
+	return &loan, nil
+}
+
+func (s *MemoryLoanStore) ActiveForUserAndBook(userID, bookID string) (*Loan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, l := range s.loans {
+		if l.UserID == userID && l.BookID == bookID && l.ReturnedAt == nil {
+			loan := l
+			return &loan, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (s *MemoryLoanStore) ListActiveForUser(userID string) ([]Loan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var active []Loan
+	for _, l := range s.loans {
+		if l.UserID == userID && l.ReturnedAt == nil {
+			active = append(active, l)
+		}
+	}
+
+	return active, nil
+}
+
+func (s *MemoryLoanStore) MarkReturned(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, l := range s.loans {
+		if l.ID == id {
+			now := time.Now()
+			s.loans[i].ReturnedAt = &now
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
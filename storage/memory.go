@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MemoryStore is an in-memory BookStore backed by a sync.RWMutex. It is
+// the default backend and is mainly useful for local development and
+// tests, since nothing is persisted across restarts.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	books []Book
+}
+
+// NewMemoryStore returns a MemoryStore seeded with the given books.
+func NewMemoryStore(seed []Book) *MemoryStore {
+	return &MemoryStore{books: seed}
+}
+
+func (s *MemoryStore) List() ([]Book, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]Book, len(s.books))
+	copy(out, s.books)
+	return out, nil
+}
+
+func (s *MemoryStore) Get(id string) (*Book, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, b := range s.books {
+		if b.ID == id {
+			book := b
+			return &book, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) Create(b Book) (*Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.books {
+		if existing.ID == b.ID {
+			return nil, fmt.Errorf("book with id %q already exists", b.ID)
+		}
+	}
+
+	s.books = append(s.books, b)
+	return &b, nil
+}
+
+func (s *MemoryStore) Update(b Book) (*Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.books {
+		if existing.ID == b.ID {
+			s.books[i] = b
+			book := s.books[i]
+			return &book, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, b := range s.books {
+		if b.ID == id {
+			s.books = append(s.books[:i], s.books[i+1:]...)
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+func (s *MemoryStore) AdjustQuantity(id string, delta int) (*Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, b := range s.books {
+		if b.ID == id {
+			if b.Quantity+delta < 0 {
+				return nil, fmt.Errorf("book %q has no available copies", id)
+			}
+			s.books[i].Quantity += delta
+			book := s.books[i]
+			return &book, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestMemoryStoreAdjustQuantityConcurrentCheckouts hammers AdjustQuantity
+// from many goroutines at once to prove the quantity can never be driven
+// negative, the atomicity guarantee checkout/return relies on. Run with
+// -race to also catch any data race on the backing slice.
+func TestMemoryStoreAdjustQuantityConcurrentCheckouts(t *testing.T) {
+	const quantity = 20
+	const attempts = 100
+
+	s := NewMemoryStore([]Book{{ID: "1", Title: "Dune", Author: "Frank Herbert", Quantity: quantity}})
+
+	var wg sync.WaitGroup
+	var succeeded int32
+	var mu sync.Mutex
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.AdjustQuantity("1", -1); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != quantity {
+		t.Fatalf("expected exactly %d successful checkouts, got %d", quantity, succeeded)
+	}
+
+	book, err := s.Get("1")
+	if err != nil {
+		t.Fatalf("getting book: %v", err)
+	}
+	if book.Quantity != 0 {
+		t.Fatalf("expected quantity to settle at 0, got %d", book.Quantity)
+	}
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoStore is a BookStore backed by a MongoDB collection.
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// ConnectMongo connects to uri and returns the named database. The
+// returned database is shared across the book/user/loan Mongo stores so
+// a single DB_DSN persists the whole library consistently.
+func ConnectMongo(uri, database string) (*mongo.Database, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to mongo: %w", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, fmt.Errorf("pinging mongo: %w", err)
+	}
+
+	return client.Database(database), nil
+}
+
+// NewMongoStore returns a BookStore backed by the "books" collection on db,
+// creating the unique index on id that duplicate-ID protection relies on.
+func NewMongoStore(db *mongo.Database) (*MongoStore, error) {
+	collection := db.Collection("books")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating books id index: %w", err)
+	}
+
+	return &MongoStore{collection: collection}, nil
+}
+
+func (s *MongoStore) List() ([]Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := s.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var books []Book
+	if err := cursor.All(ctx, &books); err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (s *MongoStore) Get(id string) (*Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var b Book
+	if err := s.collection.FindOne(ctx, bson.M{"id": id}).Decode(&b); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (s *MongoStore) Create(b Book) (*Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.collection.InsertOne(ctx, b); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (s *MongoStore) Update(b Book) (*Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.ReplaceOne(ctx, bson.M{"id": b.ID}, b)
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, ErrNotFound
+	}
+
+	return &b, nil
+}
+
+func (s *MongoStore) Delete(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := s.collection.DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AdjustQuantity uses findOneAndUpdate with a filter on the current
+// quantity so that concurrent checkout/return calls can't push the
+// quantity negative.
+func (s *MongoStore) AdjustQuantity(id string, delta int) (*Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"id": id}
+	if delta < 0 {
+		filter["quantity"] = bson.M{"$gte": -delta}
+	}
+
+	update := bson.M{"$inc": bson.M{"quantity": delta}}
+	after := options.After
+
+	var b Book
+	err := s.collection.FindOneAndUpdate(ctx, filter, update, &options.FindOneAndUpdateOptions{ReturnDocument: &after}).Decode(&b)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			if _, getErr := s.Get(id); getErr == ErrNotFound {
+				return nil, ErrNotFound
+			}
+			return nil, fmt.Errorf("book %q has no available copies", id)
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
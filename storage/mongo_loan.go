@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MongoLoanStore is a LoanStore backed by a MongoDB collection.
+type MongoLoanStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoLoanStore returns a LoanStore backed by the "loans" collection on db.
+func NewMongoLoanStore(db *mongo.Database) *MongoLoanStore {
+	return &MongoLoanStore{collection: db.Collection("loans")}
+}
+
+func (s *MongoLoanStore) Create(l Loan) (*Loan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.collection.InsertOne(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func (s *MongoLoanStore) ActiveForUserAndBook(userID, bookID string) (*Loan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var l Loan
+	filter := bson.M{"user_id": userID, "book_id": bookID, "returned_at": nil}
+	if err := s.collection.FindOne(ctx, filter).Decode(&l); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &l, nil
+}
+
+func (s *MongoLoanStore) ListActiveForUser(userID string) ([]Loan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": userID, "returned_at": nil})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var active []Loan
+	if err := cursor.All(ctx, &active); err != nil {
+		return nil, err
+	}
+
+	return active, nil
+}
+
+func (s *MongoLoanStore) MarkReturned(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	result, err := s.collection.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"returned_at": now}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
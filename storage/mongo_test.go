@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestLoanBSONFieldNames guards against the Mongo driver's default
+// lowercasing (UserID -> userid) silently diverging from the snake_case
+// keys the Mongo store's queries and updates filter on.
+func TestLoanBSONFieldNames(t *testing.T) {
+	loan := Loan{ID: "l1", UserID: "u1", BookID: "b1"}
+
+	raw, err := bson.Marshal(loan)
+	if err != nil {
+		t.Fatalf("marshaling loan: %v", err)
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("unmarshaling loan: %v", err)
+	}
+
+	for _, key := range []string{"user_id", "book_id", "checked_out_at", "due_at"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("expected bson document to contain key %q, got %+v", key, doc)
+		}
+	}
+}
+
+// TestUserBSONFieldNames guards against the same class of bug for User.
+func TestUserBSONFieldNames(t *testing.T) {
+	user := User{ID: "u1", Username: "alice", PasswordHash: "hash"}
+
+	raw, err := bson.Marshal(user)
+	if err != nil {
+		t.Fatalf("marshaling user: %v", err)
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		t.Fatalf("unmarshaling user: %v", err)
+	}
+
+	if _, ok := doc["password_hash"]; !ok {
+		t.Errorf("expected bson document to contain key %q, got %+v", "password_hash", doc)
+	}
+}
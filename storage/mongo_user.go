@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoUserStore is a UserStore backed by a MongoDB collection.
+type MongoUserStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoUserStore returns a UserStore backed by the "users" collection on
+// db, creating the unique index on username that ErrUserExists relies on.
+func NewMongoUserStore(db *mongo.Database) (*MongoUserStore, error) {
+	collection := db.Collection("users")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "username", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating users username index: %w", err)
+	}
+
+	return &MongoUserStore{collection: collection}, nil
+}
+
+func (s *MongoUserStore) Create(u User) (*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.collection.InsertOne(ctx, u); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func (s *MongoUserStore) GetByUsername(username string) (*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var u User
+	if err := s.collection.FindOne(ctx, bson.M{"username": username}).Decode(&u); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func (s *MongoUserStore) GetByID(id string) (*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var u User
+	if err := s.collection.FindOne(ctx, bson.M{"id": id}).Decode(&u); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &u, nil
+}
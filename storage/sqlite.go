@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"fmt"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteBook is the GORM model backing the sqlite table. It mirrors Book
+// but keeps the storage-layer type separate from the wire format.
+type sqliteBook struct {
+	ID       string `gorm:"primaryKey"`
+	Title    string
+	Author   string
+	Quantity int
+}
+
+func (sqliteBook) TableName() string { return "books" }
+
+// SQLiteStore is a BookStore backed by a SQLite database via GORM.
+type SQLiteStore struct {
+	db *gorm.DB
+}
+
+// OpenSQLiteDB opens (creating if necessary) the SQLite database at dsn.
+// The returned connection is shared across the book/user/loan SQLite
+// stores so a single DB_DSN persists the whole library consistently.
+func OpenSQLiteDB(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite db: %w", err)
+	}
+
+	return db, nil
+}
+
+// NewSQLiteStore migrates the books table on db and returns a BookStore
+// backed by it.
+func NewSQLiteStore(db *gorm.DB) (*SQLiteStore, error) {
+	if err := db.AutoMigrate(&sqliteBook{}); err != nil {
+		return nil, fmt.Errorf("migrating books table: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func toBook(b sqliteBook) Book {
+	return Book{ID: b.ID, Title: b.Title, Author: b.Author, Quantity: b.Quantity}
+}
+
+func fromBook(b Book) sqliteBook {
+	return sqliteBook{ID: b.ID, Title: b.Title, Author: b.Author, Quantity: b.Quantity}
+}
+
+func (s *SQLiteStore) List() ([]Book, error) {
+	var rows []sqliteBook
+	if err := s.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	books := make([]Book, len(rows))
+	for i, r := range rows {
+		books[i] = toBook(r)
+	}
+	return books, nil
+}
+
+func (s *SQLiteStore) Get(id string) (*Book, error) {
+	var row sqliteBook
+	if err := s.db.First(&row, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	book := toBook(row)
+	return &book, nil
+}
+
+func (s *SQLiteStore) Create(b Book) (*Book, error) {
+	row := fromBook(b)
+	if err := s.db.Create(&row).Error; err != nil {
+		return nil, err
+	}
+
+	book := toBook(row)
+	return &book, nil
+}
+
+func (s *SQLiteStore) Update(b Book) (*Book, error) {
+	row := fromBook(b)
+	result := s.db.Model(&sqliteBook{}).Where("id = ?", b.ID).Select("title", "author", "quantity").Updates(row)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrNotFound
+	}
+
+	return s.Get(b.ID)
+}
+
+func (s *SQLiteStore) Delete(id string) error {
+	result := s.db.Delete(&sqliteBook{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AdjustQuantity runs inside a transaction so that concurrent
+// checkout/return calls can't push the quantity negative.
+func (s *SQLiteStore) AdjustQuantity(id string, delta int) (*Book, error) {
+	var updated Book
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var row sqliteBook
+		if err := tx.Set("gorm:query_option", "FOR UPDATE").First(&row, "id = ?", id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		if row.Quantity+delta < 0 {
+			return fmt.Errorf("book %q has no available copies", id)
+		}
+
+		row.Quantity += delta
+		if err := tx.Save(&row).Error; err != nil {
+			return err
+		}
+
+		updated = toBook(row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
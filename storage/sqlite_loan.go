@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sqliteLoan is the GORM model backing the sqlite loans table.
+type sqliteLoan struct {
+	ID           string `gorm:"primaryKey"`
+	UserID       string `gorm:"index"`
+	BookID       string `gorm:"index"`
+	CheckedOutAt time.Time
+	DueAt        time.Time
+	ReturnedAt   *time.Time
+}
+
+func (sqliteLoan) TableName() string { return "loans" }
+
+// SQLiteLoanStore is a LoanStore backed by a SQLite database via GORM.
+type SQLiteLoanStore struct {
+	db *gorm.DB
+}
+
+// NewSQLiteLoanStore migrates the loans table on db and returns a
+// LoanStore backed by it.
+func NewSQLiteLoanStore(db *gorm.DB) (*SQLiteLoanStore, error) {
+	if err := db.AutoMigrate(&sqliteLoan{}); err != nil {
+		return nil, fmt.Errorf("migrating loans table: %w", err)
+	}
+
+	return &SQLiteLoanStore{db: db}, nil
+}
+
+func toLoan(row sqliteLoan) Loan {
+	return Loan{
+		ID:           row.ID,
+		UserID:       row.UserID,
+		BookID:       row.BookID,
+		CheckedOutAt: row.CheckedOutAt,
+		DueAt:        row.DueAt,
+		ReturnedAt:   row.ReturnedAt,
+	}
+}
+
+func fromLoan(l Loan) sqliteLoan {
+	return sqliteLoan{
+		ID:           l.ID,
+		UserID:       l.UserID,
+		BookID:       l.BookID,
+		CheckedOutAt: l.CheckedOutAt,
+		DueAt:        l.DueAt,
+		ReturnedAt:   l.ReturnedAt,
+	}
+}
+
+func (s *SQLiteLoanStore) Create(l Loan) (*Loan, error) {
+	row := fromLoan(l)
+	if err := s.db.Create(&row).Error; err != nil {
+		return nil, err
+	}
+
+	loan := toLoan(row)
+	return &loan, nil
+}
+
+func (s *SQLiteLoanStore) ActiveForUserAndBook(userID, bookID string) (*Loan, error) {
+	var row sqliteLoan
+	err := s.db.Where("user_id = ? AND book_id = ? AND returned_at IS NULL", userID, bookID).First(&row).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	loan := toLoan(row)
+	return &loan, nil
+}
+
+func (s *SQLiteLoanStore) ListActiveForUser(userID string) ([]Loan, error) {
+	var rows []sqliteLoan
+	if err := s.db.Where("user_id = ? AND returned_at IS NULL", userID).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	active := make([]Loan, len(rows))
+	for i, r := range rows {
+		active[i] = toLoan(r)
+	}
+	return active, nil
+}
+
+func (s *SQLiteLoanStore) MarkReturned(id string) error {
+	now := time.Now()
+	result := s.db.Model(&sqliteLoan{}).Where("id = ?", id).Update("returned_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
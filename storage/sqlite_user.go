@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// sqliteUser is the GORM model backing the sqlite users table.
+type sqliteUser struct {
+	ID           string `gorm:"primaryKey"`
+	Username     string `gorm:"uniqueIndex"`
+	PasswordHash string
+}
+
+func (sqliteUser) TableName() string { return "users" }
+
+// SQLiteUserStore is a UserStore backed by a SQLite database via GORM.
+type SQLiteUserStore struct {
+	db *gorm.DB
+}
+
+// NewSQLiteUserStore migrates the users table on db and returns a
+// UserStore backed by it.
+func NewSQLiteUserStore(db *gorm.DB) (*SQLiteUserStore, error) {
+	if err := db.AutoMigrate(&sqliteUser{}); err != nil {
+		return nil, fmt.Errorf("migrating users table: %w", err)
+	}
+
+	return &SQLiteUserStore{db: db}, nil
+}
+
+func toUser(row sqliteUser) User {
+	return User{ID: row.ID, Username: row.Username, PasswordHash: row.PasswordHash}
+}
+
+func fromUser(u User) sqliteUser {
+	return sqliteUser{ID: u.ID, Username: u.Username, PasswordHash: u.PasswordHash}
+}
+
+func (s *SQLiteUserStore) Create(u User) (*User, error) {
+	row := fromUser(u)
+	if err := s.db.Create(&row).Error; err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+
+	user := toUser(row)
+	return &user, nil
+}
+
+func (s *SQLiteUserStore) GetByUsername(username string) (*User, error) {
+	var row sqliteUser
+	if err := s.db.First(&row, "username = ?", username).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	user := toUser(row)
+	return &user, nil
+}
+
+func (s *SQLiteUserStore) GetByID(id string) (*User, error) {
+	var row sqliteUser
+	if err := s.db.First(&row, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	user := toUser(row)
+	return &user, nil
+}
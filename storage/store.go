@@ -0,0 +1,33 @@
+// Package storage defines the persistence layer for books, with
+// pluggable backends selected at startup.
+package storage
+
+import "errors"
+
+// ErrNotFound is returned by a BookStore when a book cannot be located.
+var ErrNotFound = errors.New("book not found")
+
+// Book represents a single library book record. bson tags are explicit
+// (rather than relying on the driver's default lowercasing) so the
+// Mongo backend's field names are obvious and stable regardless of
+// struct field naming.
+type Book struct {
+	ID       string `json:"id" bson:"id"`
+	Title    string `json:"title" bson:"title"`
+	Author   string `json:"author" bson:"author"`
+	Quantity int    `json:"quantity" bson:"quantity"`
+}
+
+// BookStore is implemented by every storage backend (in-memory, SQLite,
+// MongoDB, ...). Implementations must be safe for concurrent use.
+type BookStore interface {
+	List() ([]Book, error)
+	Get(id string) (*Book, error)
+	Create(b Book) (*Book, error)
+	Update(b Book) (*Book, error)
+	Delete(id string) error
+	// AdjustQuantity atomically applies delta to the book's quantity and
+	// returns the updated book. It must fail rather than let the
+	// quantity go negative, so that concurrent checkouts can't oversell.
+	AdjustQuantity(id string, delta int) (*Book, error)
+}
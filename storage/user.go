@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrUserExists is returned by Create when the username is already taken.
+var ErrUserExists = errors.New("user already exists")
+
+// User is a registered library member. PasswordHash is never serialized
+// to JSON. bson tags are explicit (rather than relying on the driver's
+// default lowercasing) so the Mongo backend's field names are obvious
+// and stable regardless of struct field naming.
+type User struct {
+	ID           string `json:"id" bson:"id"`
+	Username     string `json:"username" bson:"username"`
+	PasswordHash string `json:"-" bson:"password_hash"`
+}
+
+// UserStore is implemented by every user storage backend.
+type UserStore interface {
+	Create(u User) (*User, error)
+	GetByUsername(username string) (*User, error)
+	GetByID(id string) (*User, error)
+}
+
+// MemoryUserStore is an in-memory UserStore backed by a sync.RWMutex.
+type MemoryUserStore struct {
+	mu    sync.RWMutex
+	users []User
+}
+
+// NewMemoryUserStore returns an empty MemoryUserStore.
+func NewMemoryUserStore() *MemoryUserStore {
+	return &MemoryUserStore{}
+}
+
+func (s *MemoryUserStore) Create(u User) (*User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.users {
+		if existing.Username == u.Username {
+			return nil, ErrUserExists
+		}
+	}
+
+	s.users = append(s.users, u)
+	return &u, nil
+}
+
+func (s *MemoryUserStore) GetByUsername(username string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.Username == username {
+			user := u
+			return &user, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
+
+func (s *MemoryUserStore) GetByID(id string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, u := range s.users {
+		if u.ID == id {
+			user := u
+			return &user, nil
+		}
+	}
+
+	return nil, ErrNotFound
+}
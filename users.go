@@ -0,0 +1,123 @@
+package main
+
+import (
+	"net/http"
+
+	"example/go-rest-api/auth"
+	"example/go-rest-api/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// userStore holds registered members, selected in main via STORAGE_DRIVER.
+var userStore storage.UserStore
+
+// loans holds checkout/return history, keyed by user and book, selected
+// in main via STORAGE_DRIVER.
+var loans storage.LoanStore
+
+// RegisterInput is the request body for POST /register.
+type RegisterInput struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// LoginInput is the request body for POST /login.
+type LoginInput struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// LoginResponse carries the issued JWT.
+type LoginResponse struct {
+	Token string `json:"token"`
+}
+
+// @Summary Register a new user
+// @Description Create a library member account
+// @Tags Users
+// @Accept  json
+// @Produce  json
+// @Param user body RegisterInput true "Registration data"
+// @Success 201
+// @Failure 400 {object} ErrorResponse
+// @Router /register [post]
+func registerUser(c *gin.Context) {
+	var input RegisterInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "hash_error", "Could not register user.")
+		return
+	}
+
+	_, err = userStore.Create(storage.User{
+		ID:           uuid.NewString(),
+		Username:     input.Username,
+		PasswordHash: string(hash),
+	})
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "duplicate_username", "Username is already taken.")
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// @Summary Log in
+// @Description Exchange a username and password for a JWT
+// @Tags Users
+// @Accept  json
+// @Produce  json
+// @Param credentials body LoginInput true "Login credentials"
+// @Success 200 {object} LoginResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /login [post]
+func loginUser(c *gin.Context) {
+	var input LoginInput
+	if !bindJSON(c, &input) {
+		return
+	}
+
+	user, err := userStore.GetByUsername(input.Username)
+	if err != nil {
+		respondError(c, http.StatusUnauthorized, "invalid_credentials", "Invalid username or password.")
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		respondError(c, http.StatusUnauthorized, "invalid_credentials", "Invalid username or password.")
+		return
+	}
+
+	token, err := auth.GenerateToken(user.ID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "token_error", "Could not issue token.")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, LoginResponse{Token: token})
+}
+
+// @Summary Get my active loans
+// @Description List the caller's books that are currently checked out
+// @Tags Users
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} storage.Loan
+// @Failure 401 {object} ErrorResponse
+// @Router /me/loans [get]
+func myLoans(c *gin.Context) {
+	active, err := loans.ListActiveForUser(auth.UserID(c))
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "storage_error", "Could not list loans.")
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, active)
+}